@@ -0,0 +1,92 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Browser opens a URL in the user's default browser.
+type Browser interface {
+	Open(rawURL string) error
+}
+
+// newBrowser returns the Browser implementation for the current platform. WSL is
+// detected explicitly since it reports runtime.GOOS == "linux" but has no working
+// xdg-open/gio stack of its own.
+func newBrowser() Browser {
+	return osBrowser{}
+}
+
+type osBrowser struct{}
+
+func (osBrowser) Open(rawURL string) error {
+	switch {
+	case isWSL():
+		return openWSL(rawURL)
+	case runtime.GOOS == "darwin":
+		return exec.Command("open", rawURL).Run()
+	case runtime.GOOS == "windows":
+		return openWindows(rawURL)
+	case runtime.GOOS == "linux":
+		return openLinux(rawURL)
+	default:
+		return fmt.Errorf("unsupported OS for opening a browser: %s", runtime.GOOS)
+	}
+}
+
+func openWindows(rawURL string) error {
+	// Try rundll32 first
+	if err := exec.Command("rundll32", "url.dll,FileProtocolHandler", rawURL).Run(); err == nil {
+		return nil
+	}
+	// Fallback to cmd start
+	return exec.Command("cmd", "/c", "start", "", rawURL).Run()
+}
+
+func openLinux(rawURL string) error {
+	if path, err := exec.LookPath("xdg-open"); err == nil {
+		if err := exec.Command(path, rawURL).Run(); err == nil {
+			return nil
+		}
+	}
+	if path, err := exec.LookPath("gio"); err == nil {
+		if err := exec.Command(path, "open", rawURL).Run(); err == nil {
+			return nil
+		}
+	}
+	if browser := os.Getenv("BROWSER"); browser != "" {
+		return exec.Command(browser, rawURL).Run()
+	}
+	return fmt.Errorf("no way to open a browser found (tried xdg-open, gio open, $BROWSER)")
+}
+
+func openWSL(rawURL string) error {
+	if path, err := exec.LookPath("wslview"); err == nil {
+		if err := exec.Command(path, rawURL).Run(); err == nil {
+			return nil
+		}
+	}
+	if path, err := exec.LookPath("powershell.exe"); err == nil {
+		if err := exec.Command(path, "Start-Process", rawURL).Run(); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no way to open a browser found under WSL (tried wslview, powershell.exe Start-Process)")
+}
+
+// isWSL reports whether we're running under Windows Subsystem for Linux, where
+// runtime.GOOS is "linux" but the usual Linux browser-launch tools aren't present.
+func isWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	data, err := os.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+	lower := strings.ToLower(string(data))
+	return strings.Contains(lower, "microsoft") || strings.Contains(lower, "wsl")
+}
@@ -0,0 +1,219 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fakeServerState is one server's mutable state as tracked by fakeAPIServer.
+type fakeServerState struct {
+	enabled       bool
+	connected     bool
+	autoReconnect bool
+}
+
+// fakeAPIServer is a minimal stand-in for the mcpproxy admin API: enough of
+// /api/v1/servers and its per-server actions to drive BulkServerOp end-to-end.
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	servers map[string]*fakeServerState
+}
+
+func newFakeAPIServer() *fakeAPIServer {
+	return &fakeAPIServer{servers: map[string]*fakeServerState{}}
+}
+
+// addServer registers a server. If autoReconnect is true, a restart marks it
+// disconnected and then reconnects it shortly afterward, simulating a server that
+// comes back healthy; otherwise it stays disconnected, since no action of this fake
+// (enable included) reconnects it.
+func (f *fakeAPIServer) addServer(name string, connected, autoReconnect bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.servers[name] = &fakeServerState{enabled: true, connected: connected, autoReconnect: autoReconnect}
+}
+
+func (f *fakeAPIServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/servers", f.handleList)
+	mux.HandleFunc("/api/v1/servers/", f.handleAction)
+	return mux
+}
+
+func (f *fakeAPIServer) handleList(w http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	servers := make([]Server, 0, len(f.servers))
+	for name, s := range f.servers {
+		servers = append(servers, Server{Name: name, Enabled: s.enabled, Connected: s.connected})
+	}
+	f.mu.Unlock()
+
+	writeJSONResponse(w, Response{Success: true, Data: map[string]interface{}{"servers": servers}})
+}
+
+func (f *fakeAPIServer) handleAction(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/servers/"), "/")
+	if len(parts) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	name, action := parts[0], parts[1]
+
+	f.mu.Lock()
+	state, ok := f.servers[name]
+	f.mu.Unlock()
+	if !ok {
+		writeJSONResponse(w, Response{Success: false, Error: "unknown server"})
+		return
+	}
+
+	switch action {
+	case "enable":
+		f.mu.Lock()
+		state.enabled = true
+		f.mu.Unlock()
+	case "disable":
+		f.mu.Lock()
+		state.enabled = false
+		state.connected = false
+		f.mu.Unlock()
+	case "restart":
+		f.mu.Lock()
+		state.connected = false
+		autoReconnect := state.autoReconnect
+		f.mu.Unlock()
+		if autoReconnect {
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				f.mu.Lock()
+				state.connected = true
+				f.mu.Unlock()
+			}()
+		}
+	case "login":
+		// No state change needed for the tests that exercise this.
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSONResponse(w, Response{Success: true})
+}
+
+func writeJSONResponse(w http.ResponseWriter, resp Response) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func newTestClient(t *testing.T, baseURL string) *Client {
+	t.Helper()
+	client, err := NewClient(baseURL, zap.NewNop().Sugar())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func collectProgress(ch <-chan BulkOpProgress) []BulkOpProgress {
+	var got []BulkOpProgress
+	for p := range ch {
+		got = append(got, p)
+	}
+	return got
+}
+
+func phasesOf(progress []BulkOpProgress) []BulkOpPhase {
+	phases := make([]BulkOpPhase, len(progress))
+	for i, p := range progress {
+		phases[i] = p.Phase
+	}
+	return phases
+}
+
+func TestBulkServerOp(t *testing.T) {
+	cases := []struct {
+		name            string
+		op              ServerOp
+		serverConnected bool
+		autoReconnect   bool
+		healthyTimeout  time.Duration
+		wantPhases      []BulkOpPhase
+		wantFinalErr    bool
+	}{
+		{
+			// Regression test: WaitHealthy must be a no-op for enable, since a newly
+			// enabled server isn't expected to already report Connected==true.
+			name:            "enable ignores WaitHealthy",
+			op:              ServerOpEnable,
+			serverConnected: false,
+			healthyTimeout:  200 * time.Millisecond,
+			wantPhases:      []BulkOpPhase{BulkOpPhaseStarted, BulkOpPhaseFinished},
+		},
+		{
+			// Regression test: disabling a server must not wait for Connected==true,
+			// which a disabled server will never report.
+			name:            "disable ignores WaitHealthy",
+			op:              ServerOpDisable,
+			serverConnected: true,
+			healthyTimeout:  200 * time.Millisecond,
+			wantPhases:      []BulkOpPhase{BulkOpPhaseStarted, BulkOpPhaseFinished},
+		},
+		{
+			name:            "restart waits for the server to reconnect",
+			op:              ServerOpRestart,
+			serverConnected: true,
+			autoReconnect:   true,
+			healthyTimeout:  2 * time.Second,
+			wantPhases:      []BulkOpPhase{BulkOpPhaseStarted, BulkOpPhaseWaitingHealthy, BulkOpPhaseFinished},
+		},
+		{
+			name:            "restart reports an error when the server never reconnects",
+			op:              ServerOpRestart,
+			serverConnected: true,
+			autoReconnect:   false,
+			healthyTimeout:  50 * time.Millisecond,
+			wantPhases:      []BulkOpPhase{BulkOpPhaseStarted, BulkOpPhaseWaitingHealthy, BulkOpPhaseError},
+			wantFinalErr:    true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fake := newFakeAPIServer()
+			fake.addServer("alpha", tc.serverConnected, tc.autoReconnect)
+			srv := httptest.NewServer(fake.handler())
+			defer srv.Close()
+
+			client := newTestClient(t, srv.URL)
+
+			progressCh := client.BulkServerOp(context.Background(), tc.op, []string{"alpha"}, BulkServerOpOptions{
+				WaitHealthy:    true,
+				HealthyTimeout: tc.healthyTimeout,
+			})
+
+			progress := collectProgress(progressCh)
+			phases := phasesOf(progress)
+
+			if !reflect.DeepEqual(phases, tc.wantPhases) {
+				t.Fatalf("phases = %v, want %v", phases, tc.wantPhases)
+			}
+
+			last := progress[len(progress)-1]
+			if tc.wantFinalErr && last.Error == nil {
+				t.Fatal("expected a final error, got nil")
+			}
+			if !tc.wantFinalErr && last.Error != nil {
+				t.Fatalf("unexpected error: %v", last.Error)
+			}
+		})
+	}
+}
@@ -1,20 +1,21 @@
-//go:build darwin || windows
-
 package api
 
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -69,6 +70,22 @@ type StatusUpdate struct {
 	Timestamp     int64                  `json:"timestamp"`
 }
 
+// Event is a single typed SSE event delivered to subscribers beyond the legacy
+// "status" channel, e.g. server.added, tool.invoked, oauth.pending.
+type Event struct {
+	Type string
+	Data string
+}
+
+const (
+	// defaultSSEIdleTimeout is how long connectSSE waits without receiving any line
+	// (event, comment, or heartbeat) before forcing a reconnect.
+	defaultSSEIdleTimeout = 45 * time.Second
+	// defaultSSEStableThreshold is how long a connection must stay up before a
+	// subsequent disconnect is treated as a fresh failure sequence (attemptCount reset).
+	defaultSSEStableThreshold = 30 * time.Second
+)
+
 // Client provides access to the mcpproxy API
 type Client struct {
 	baseURL           string
@@ -76,16 +93,85 @@ type Client struct {
 	httpClient        *http.Client
 	logger            *zap.SugaredLogger
 	statusCh          chan StatusUpdate
+	eventCh           chan Event
 	sseCancel         context.CancelFunc
 	connectionStateCh chan tray.ConnectionState
+
+	lastEventID      string
+	sseIdleTimeout   time.Duration
+	sseStableAfter   time.Duration
+	sseRetryOverride time.Duration
+}
+
+// WithSSEIdleTimeout overrides the default idle timeout (45s) used by the SSE
+// heartbeat watchdog: if no event, comment, or heartbeat line arrives within this
+// window, the connection is force-closed and a reconnect is triggered.
+func WithSSEIdleTimeout(d time.Duration) Option {
+	return func(c *Client) error {
+		if d <= 0 {
+			return fmt.Errorf("SSE idle timeout must be positive, got %s", d)
+		}
+		c.sseIdleTimeout = d
+		return nil
+	}
+}
+
+// Option configures a Client at construction time. Options are applied in order and
+// may return an error, e.g. when a TLS option references a cert/key that can't be loaded.
+type Option func(*Client) error
+
+// TLSOptions configures how the client establishes and verifies TLS trust with the
+// proxy's admin API. The zero value preserves the historical behavior: system cert
+// pool plus the local mcpproxy CA if present, server-verification only.
+type TLSOptions struct {
+	// ClientCertFile and ClientKeyFile, if both set, are presented to the server for
+	// mutual TLS authentication against the admin API.
+	ClientCertFile string
+	ClientKeyFile  string
+
+	// PinOnly, when true, discards the system cert pool entirely and trusts only the
+	// local mcpproxy CA, so a compromised public CA cannot impersonate the daemon.
+	PinOnly bool
+
+	// ServerName overrides the SNI/hostname used for certificate verification. Useful
+	// when baseURL is an IP address or the CA was issued for a different name.
+	ServerName string
+
+	// PinnedSPKIHashes, if non-empty, are base64-encoded SHA-256 hashes of acceptable
+	// leaf certificate SubjectPublicKeyInfo values. The peer certificate must match one
+	// of them or the handshake is rejected, independent of CA trust.
+	PinnedSPKIHashes []string
 }
 
-// NewClient creates a new API client
-func NewClient(baseURL string, logger *zap.SugaredLogger) *Client {
+// WithTLSOptions returns an Option that rebuilds the client's TLS configuration
+// according to opts, replacing the default CA-trust behavior.
+func WithTLSOptions(opts TLSOptions) Option {
+	return func(c *Client) error {
+		tlsConfig, err := buildTLSConfig(c.logger, opts)
+		if err != nil {
+			return fmt.Errorf("failed to build TLS config: %w", err)
+		}
+
+		transport, ok := c.httpClient.Transport.(*http.Transport)
+		if !ok {
+			return fmt.Errorf("unexpected transport type %T", c.httpClient.Transport)
+		}
+		transport.TLSClientConfig = tlsConfig
+		return nil
+	}
+}
+
+// NewClient creates a new API client. By default it trusts the system cert pool plus
+// the local mcpproxy CA (if present); pass WithTLSOptions to enable mTLS, pinned-CA-only
+// trust, or SPKI pinning.
+func NewClient(baseURL string, logger *zap.SugaredLogger, opts ...Option) (*Client, error) {
 	// Create TLS config that trusts the local CA
-	tlsConfig := createTLSConfig(logger)
+	tlsConfig, err := buildTLSConfig(logger, TLSOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build default TLS config: %w", err)
+	}
 
-	return &Client{
+	c := &Client{
 		baseURL: strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 0,
@@ -95,8 +181,19 @@ func NewClient(baseURL string, logger *zap.SugaredLogger) *Client {
 		},
 		logger:            logger,
 		statusCh:          make(chan StatusUpdate, 10),
+		eventCh:           make(chan Event, 32),
 		connectionStateCh: make(chan tray.ConnectionState, 8),
+		sseIdleTimeout:    defaultSSEIdleTimeout,
+		sseStableAfter:    defaultSSEStableThreshold,
 	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 // SetAPIKey sets the API key for authentication
@@ -113,6 +210,7 @@ func (c *Client) StartSSE(ctx context.Context) error {
 
 	go func() {
 		defer close(c.statusCh)
+		defer close(c.eventCh)
 		defer close(c.connectionStateCh)
 
 		attemptCount := 0
@@ -128,6 +226,13 @@ func (c *Client) StartSSE(ctx context.Context) error {
 
 			attemptCount++
 
+			// The server may have overridden our base delay via an SSE "retry:" field
+			// on the previous connection; honor it until told otherwise.
+			effectiveBaseDelay := baseDelay
+			if c.sseRetryOverride > 0 {
+				effectiveBaseDelay = c.sseRetryOverride
+			}
+
 			// Calculate exponential backoff delay
 			minVal := attemptCount - 1
 			if minVal > 4 {
@@ -137,7 +242,7 @@ func (c *Client) StartSSE(ctx context.Context) error {
 				minVal = 0
 			}
 			backoffFactor := 1 << minVal
-			delay := time.Duration(int64(baseDelay) * int64(backoffFactor))
+			delay := time.Duration(int64(effectiveBaseDelay) * int64(backoffFactor))
 			if delay > maxDelay {
 				delay = maxDelay
 			}
@@ -174,12 +279,28 @@ func (c *Client) StartSSE(ctx context.Context) error {
 
 			c.publishConnectionState(tray.ConnectionStateConnecting)
 
-			if err := c.connectSSE(sseCtx); err != nil {
+			stableFor, err := c.connectSSE(sseCtx)
+
+			// Only treat the disconnect as "recovered" once the connection has proven
+			// itself stable for sseStableAfter; a connection that drops immediately
+			// after the handshake should keep climbing the backoff schedule.
+			if stableFor >= c.sseStableAfter {
+				if attemptCount > 1 && c.logger != nil {
+					c.logger.Info("SSE connection was stable, resetting backoff",
+						"stable_for", stableFor,
+						"after_attempts", attemptCount,
+						"base_url", c.baseURL)
+				}
+				attemptCount = 0
+			}
+
+			if err != nil {
 				if c.logger != nil {
 					c.logger.Error("SSE connection error",
 						"error", err,
 						"attempt", attemptCount,
 						"max_retries", maxRetries,
+						"stable_for", stableFor,
 						"base_url", c.baseURL)
 				}
 
@@ -193,13 +314,11 @@ func (c *Client) StartSSE(ctx context.Context) error {
 				continue
 			}
 
-			// Successful connection - reset attempt count
-			if attemptCount > 1 && c.logger != nil {
-				c.logger.Info("SSE connection established successfully",
-					"after_attempts", attemptCount,
+			if c.logger != nil {
+				c.logger.Info("SSE stream closed by server, reconnecting",
+					"stable_for", stableFor,
 					"base_url", c.baseURL)
 			}
-			attemptCount = 0
 		}
 	}()
 
@@ -213,18 +332,31 @@ func (c *Client) StopSSE() {
 	}
 }
 
-// StatusChannel returns the channel for status updates
+// StatusChannel returns the channel for status updates, kept for backward
+// compatibility. processSSEEvent delivers "status" events here and to EventChannel
+// independently from the same raw SSE event; this channel is not derived from
+// EventChannel.
 func (c *Client) StatusChannel() <-chan StatusUpdate {
 	return c.statusCh
 }
 
+// EventChannel returns the channel of typed SSE events, including but not limited to
+// "status" (e.g. "server.added", "tool.invoked", "oauth.pending"), for callers that
+// need more than the status-only view.
+func (c *Client) EventChannel() <-chan Event {
+	return c.eventCh
+}
+
 // ConnectionStateChannel exposes connectivity updates for tray consumers.
 func (c *Client) ConnectionStateChannel() <-chan tray.ConnectionState {
 	return c.connectionStateCh
 }
 
-// connectSSE establishes the SSE connection and processes events
-func (c *Client) connectSSE(ctx context.Context) error {
+// connectSSE establishes the SSE connection and processes events until the stream
+// ends, the context is cancelled, or the idle watchdog force-closes it. It returns
+// how long the connection stayed up once it reached HTTP 200, which the caller uses
+// to decide whether to reset its reconnect backoff.
+func (c *Client) connectSSE(ctx context.Context) (time.Duration, error) {
 	url := c.baseURL + "/events"
 	if c.apiKey != "" {
 		url += "?apikey=" + c.apiKey
@@ -232,41 +364,68 @@ func (c *Client) connectSSE(ctx context.Context) error {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, http.NoBody)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
+	if c.lastEventID != "" {
+		// Resume from where we left off, per the SSE reconnection spec.
+		req.Header.Set("Last-Event-ID", c.lastEventID)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("SSE connection failed with status: %d", resp.StatusCode)
+		return 0, fmt.Errorf("SSE connection failed with status: %d", resp.StatusCode)
 	}
 
 	c.publishConnectionState(tray.ConnectionStateConnected)
+	connectedAt := time.Now()
+
+	// Heartbeat watchdog: force-close the body if we go sseIdleTimeout without
+	// receiving any line (event data, id, retry, or a ":" comment heartbeat).
+	idleTimer := time.AfterFunc(c.sseIdleTimeout, func() {
+		if c.logger != nil {
+			c.logger.Warn("SSE idle timeout exceeded, forcing reconnect",
+				"idle_timeout", c.sseIdleTimeout,
+				"base_url", c.baseURL)
+		}
+		resp.Body.Close()
+	})
+	defer idleTimer.Stop()
 
 	scanner := bufio.NewScanner(resp.Body)
 	var eventType string
 	var data strings.Builder
 
 	for scanner.Scan() {
+		idleTimer.Reset(c.sseIdleTimeout)
 		line := scanner.Text()
 
-		if line == "" {
+		switch {
+		case line == "":
 			// End of event, process it
 			if eventType != "" && data.Len() > 0 {
 				c.processSSEEvent(eventType, data.String())
-				eventType = ""
-				data.Reset()
 			}
-		} else if strings.HasPrefix(line, "event:") {
+			eventType = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment/heartbeat line - already reset the idle timer above, nothing else to do.
+		case strings.HasPrefix(line, "event:"):
 			eventType = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
-		} else if strings.HasPrefix(line, "data:") {
+		case strings.HasPrefix(line, "id:"):
+			c.lastEventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil && ms > 0 {
+				c.sseRetryOverride = time.Duration(ms) * time.Millisecond
+			}
+		case strings.HasPrefix(line, "data:"):
 			dataLine := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
 			if data.Len() > 0 {
 				data.WriteString("\n")
@@ -275,11 +434,20 @@ func (c *Client) connectSSE(ctx context.Context) error {
 		}
 	}
 
-	return scanner.Err()
+	return time.Since(connectedAt), scanner.Err()
 }
 
-// processSSEEvent processes incoming SSE events
+// processSSEEvent processes an incoming SSE event, fanning it out to the typed
+// Event channel and, for "status" events, to the legacy StatusChannel.
 func (c *Client) processSSEEvent(eventType, data string) {
+	select {
+	case c.eventCh <- Event{Type: eventType, Data: data}:
+	default:
+		if c.logger != nil {
+			c.logger.Debug("Dropping SSE event, event channel full", "type", eventType)
+		}
+	}
+
 	if eventType == "status" {
 		var statusUpdate StatusUpdate
 		if err := json.Unmarshal([]byte(data), &statusUpdate); err != nil {
@@ -310,8 +478,8 @@ func (c *Client) publishConnectionState(state tray.ConnectionState) {
 }
 
 // GetServers fetches the list of servers from the API
-func (c *Client) GetServers() ([]Server, error) {
-	resp, err := c.makeRequest("GET", "/api/v1/servers", nil)
+func (c *Client) GetServers(ctx context.Context) ([]Server, error) {
+	resp, err := c.makeRequest(ctx, "GET", "/api/v1/servers", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +519,7 @@ func (c *Client) GetServers() ([]Server, error) {
 }
 
 // EnableServer enables or disables a server
-func (c *Client) EnableServer(serverName string, enabled bool) error {
+func (c *Client) EnableServer(ctx context.Context, serverName string, enabled bool) error {
 	var endpoint string
 	if enabled {
 		endpoint = fmt.Sprintf("/api/v1/servers/%s/enable", serverName)
@@ -359,7 +527,7 @@ func (c *Client) EnableServer(serverName string, enabled bool) error {
 		endpoint = fmt.Sprintf("/api/v1/servers/%s/disable", serverName)
 	}
 
-	resp, err := c.makeRequest("POST", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -372,10 +540,10 @@ func (c *Client) EnableServer(serverName string, enabled bool) error {
 }
 
 // RestartServer restarts a server
-func (c *Client) RestartServer(serverName string) error {
+func (c *Client) RestartServer(ctx context.Context, serverName string) error {
 	endpoint := fmt.Sprintf("/api/v1/servers/%s/restart", serverName)
 
-	resp, err := c.makeRequest("POST", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -388,10 +556,10 @@ func (c *Client) RestartServer(serverName string) error {
 }
 
 // TriggerOAuthLogin triggers OAuth login for a server
-func (c *Client) TriggerOAuthLogin(serverName string) error {
+func (c *Client) TriggerOAuthLogin(ctx context.Context, serverName string) error {
 	endpoint := fmt.Sprintf("/api/v1/servers/%s/login", serverName)
 
-	resp, err := c.makeRequest("POST", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "POST", endpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -403,11 +571,188 @@ func (c *Client) TriggerOAuthLogin(serverName string) error {
 	return nil
 }
 
+// ServerOp identifies a bulk operation to apply across multiple servers via BulkServerOp.
+type ServerOp string
+
+const (
+	ServerOpEnable     ServerOp = "enable"
+	ServerOpDisable    ServerOp = "disable"
+	ServerOpRestart    ServerOp = "restart"
+	ServerOpOAuthLogin ServerOp = "oauth_login"
+)
+
+// BulkOpPhase describes where a single server is in a bulk operation.
+type BulkOpPhase string
+
+const (
+	BulkOpPhaseStarted        BulkOpPhase = "started"
+	BulkOpPhaseWaitingHealthy BulkOpPhase = "waiting_healthy"
+	BulkOpPhaseFinished       BulkOpPhase = "finished"
+	BulkOpPhaseError          BulkOpPhase = "error"
+)
+
+// BulkOpProgress reports the outcome of one phase of a bulk server operation for a
+// single server.
+type BulkOpProgress struct {
+	Server string
+	Phase  BulkOpPhase
+	Error  error
+}
+
+// BulkServerOpOptions configures Client.BulkServerOp.
+type BulkServerOpOptions struct {
+	// Parallelism caps how many servers are acted on concurrently. Defaults to 4.
+	Parallelism int
+
+	// WaitHealthy, if true, polls GetServers after issuing the op until the target
+	// reports Connected==true or HealthyTimeout elapses. Meaningful for restart and
+	// oauth_login; ignored for enable/disable.
+	WaitHealthy bool
+
+	// HealthyTimeout bounds how long to wait per server when WaitHealthy is set.
+	// Defaults to 2 minutes.
+	HealthyTimeout time.Duration
+}
+
+// BulkServerOp applies op to each of names, up to opts.Parallelism at a time, and
+// reports progress on the returned channel. The channel is closed once every server
+// has reached a terminal phase (finished or error). Callers should keep draining it
+// until it closes to avoid leaking the operation's goroutines.
+func (c *Client) BulkServerOp(ctx context.Context, op ServerOp, names []string, opts BulkServerOpOptions) <-chan BulkOpProgress {
+	if opts.Parallelism <= 0 {
+		opts.Parallelism = 4
+	}
+	if opts.HealthyTimeout <= 0 {
+		opts.HealthyTimeout = 2 * time.Minute
+	}
+
+	progressCh := make(chan BulkOpProgress, len(names))
+	sem := make(chan struct{}, opts.Parallelism)
+	var wg sync.WaitGroup
+
+	for _, name := range names {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseError, Error: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			c.runBulkServerOp(ctx, op, name, opts, progressCh)
+		}(name)
+	}
+
+	go func() {
+		wg.Wait()
+		close(progressCh)
+	}()
+
+	return progressCh
+}
+
+// runBulkServerOp performs op against a single server and reports each phase to
+// progressCh, optionally waiting for the server to come back healthy afterward.
+func (c *Client) runBulkServerOp(ctx context.Context, op ServerOp, name string, opts BulkServerOpOptions, progressCh chan<- BulkOpProgress) {
+	progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseStarted}
+
+	var err error
+	switch op {
+	case ServerOpEnable:
+		err = c.EnableServer(ctx, name, true)
+	case ServerOpDisable:
+		err = c.EnableServer(ctx, name, false)
+	case ServerOpRestart:
+		err = c.RestartServer(ctx, name)
+	case ServerOpOAuthLogin:
+		err = c.TriggerOAuthLogin(ctx, name)
+	default:
+		err = fmt.Errorf("unknown bulk server op %q", op)
+	}
+
+	if err != nil {
+		progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseError, Error: err}
+		return
+	}
+
+	// WaitHealthy only makes sense for ops that expect the server to come back
+	// Connected==true; a disabled server never reports Connected, so honoring it for
+	// enable/disable would just burn HealthyTimeout on a guaranteed timeout.
+	if !opts.WaitHealthy || !opWaitsForHealthy(op) {
+		progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseFinished}
+		return
+	}
+
+	progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseWaitingHealthy}
+	if err := c.waitServerHealthy(ctx, name, opts.HealthyTimeout); err != nil {
+		progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseError, Error: err}
+		return
+	}
+
+	progressCh <- BulkOpProgress{Server: name, Phase: BulkOpPhaseFinished}
+}
+
+// opWaitsForHealthy reports whether op should be followed by waitServerHealthy when
+// BulkServerOpOptions.WaitHealthy is set. Enable/disable are excluded per
+// BulkServerOpOptions.WaitHealthy's doc comment: a disabled server never reports
+// Connected==true, so waiting on it would only produce a spurious timeout.
+func opWaitsForHealthy(op ServerOp) bool {
+	switch op {
+	case ServerOpRestart, ServerOpOAuthLogin:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitServerHealthy polls GetServers, backing off exponentially like the SSE
+// reconnect loop, until name reports Connected==true or timeout elapses.
+func (c *Client) waitServerHealthy(ctx context.Context, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	baseDelay := 500 * time.Millisecond
+	maxDelay := 10 * time.Second
+
+	for attempt := 0; ; attempt++ {
+		servers, err := c.GetServers(ctx)
+		if err != nil && c.logger != nil {
+			c.logger.Debug("Failed to poll server health, retrying", "server", name, "error", err)
+		}
+		for _, s := range servers {
+			if s.Name == name && s.Connected {
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("server %q did not report healthy within %s", name, timeout)
+		}
+
+		minVal := attempt
+		if minVal > 4 {
+			minVal = 4
+		}
+		delay := time.Duration(int64(baseDelay) * int64(1<<minVal))
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
 // GetServerTools gets tools for a specific server
-func (c *Client) GetServerTools(serverName string) ([]Tool, error) {
+func (c *Client) GetServerTools(ctx context.Context, serverName string) ([]Tool, error) {
 	endpoint := fmt.Sprintf("/api/v1/servers/%s/tools", serverName)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -445,10 +790,10 @@ func (c *Client) GetServerTools(serverName string) ([]Tool, error) {
 }
 
 // SearchTools searches for tools
-func (c *Client) SearchTools(query string, limit int) ([]SearchResult, error) {
+func (c *Client) SearchTools(ctx context.Context, query string, limit int) ([]SearchResult, error) {
 	endpoint := fmt.Sprintf("/api/v1/index/search?q=%s&limit=%d", query, limit)
 
-	resp, err := c.makeRequest("GET", endpoint, nil)
+	resp, err := c.makeRequest(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -486,36 +831,56 @@ func (c *Client) SearchTools(query string, limit int) ([]SearchResult, error) {
 	return searchResults, nil
 }
 
-// OpenWebUI opens the web control panel in the default browser
+// OpenWebUI opens the web control panel in the default browser. It validates baseURL
+// and URL-encodes the apikey query parameter before handing the URL to the platform's
+// browser-launch mechanism (see Browser).
 func (c *Client) OpenWebUI() error {
-    url := c.baseURL + "/ui/"
-    if c.apiKey != "" {
-        url += "?apikey=" + c.apiKey
-    }
-    c.logger.Info("Opening web control panel", "url", c.baseURL+"/ui/")
-    switch runtime.GOOS {
-    case "darwin":
-        return exec.Command("open", url).Run()
-    case "windows":
-        // Try rundll32 first
-        if err := exec.Command("rundll32", "url.dll,FileProtocolHandler", url).Run(); err == nil {
-            return nil
-        }
-        // Fallback to cmd start
-        return exec.Command("cmd", "/c", "start", "", url).Run()
-    default:
-        return fmt.Errorf("unsupported OS for OpenWebUI: %s", runtime.GOOS)
-    }
-}
-
-// makeRequest makes an HTTP request to the API with enhanced error handling and retry logic
-func (c *Client) makeRequest(method, path string, _ interface{}) (*Response, error) {
+	target, err := c.webUIURL()
+	if err != nil {
+		return fmt.Errorf("failed to build web UI URL: %w", err)
+	}
+
+	c.logger.Info("Opening web control panel", "url", c.baseURL+"/ui/")
+	if err := newBrowser().Open(target.String()); err != nil {
+		return fmt.Errorf("failed to open web control panel: %w", err)
+	}
+	return nil
+}
+
+// webUIURL builds and validates the web control panel URL, rejecting anything that
+// isn't a well-formed http(s) URL so a malformed baseURL can't smuggle shell-hostile
+// characters into the platform browser-launch command.
+func (c *Client) webUIURL() (*url.URL, error) {
+	parsed, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL %q: %w", c.baseURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("base URL %q must use http or https, got %q", c.baseURL, parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("base URL %q is missing a host", c.baseURL)
+	}
+
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/") + "/ui/"
+	if c.apiKey != "" {
+		q := parsed.Query()
+		q.Set("apikey", c.apiKey)
+		parsed.RawQuery = q.Encode()
+	}
+	return parsed, nil
+}
+
+// makeRequest makes an HTTP request to the API with enhanced error handling and retry
+// logic. ctx is honored end-to-end: it's attached to the HTTP request itself, and
+// cancelling it aborts any retry/backoff sleep in progress instead of waiting it out.
+func (c *Client) makeRequest(ctx context.Context, method, path string, _ interface{}) (*Response, error) {
 	url := c.baseURL + path
 	maxRetries := 3
 	baseDelay := 1 * time.Second
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
-		req, err := http.NewRequest(method, url, http.NoBody)
+		req, err := http.NewRequestWithContext(ctx, method, url, http.NoBody)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
@@ -530,6 +895,9 @@ func (c *Client) makeRequest(method, path string, _ interface{}) (*Response, err
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			if attempt < maxRetries {
 				delay := time.Duration(attempt) * baseDelay
 				if c.logger != nil {
@@ -539,14 +907,16 @@ func (c *Client) makeRequest(method, path string, _ interface{}) (*Response, err
 						"delay", delay,
 						"error", err)
 				}
-				time.Sleep(delay)
+				if err := sleepOrDone(ctx, delay); err != nil {
+					return nil, err
+				}
 				continue
 			}
 			return nil, fmt.Errorf("request failed after %d attempts: %w", maxRetries, err)
 		}
 
 		// Process response with proper cleanup
-		result, shouldContinue, err := c.processResponse(resp, attempt, maxRetries, baseDelay, path)
+		result, shouldContinue, err := c.processResponse(ctx, resp, attempt, maxRetries, baseDelay, path)
 		if err != nil {
 			return nil, err
 		}
@@ -559,8 +929,22 @@ func (c *Client) makeRequest(method, path string, _ interface{}) (*Response, err
 	return nil, fmt.Errorf("unexpected error in request retry loop")
 }
 
+// sleepOrDone waits for delay, returning early with ctx.Err() if ctx is cancelled
+// first, so retry backoff never outlives the caller's context.
+func sleepOrDone(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // processResponse handles response processing with proper cleanup
-func (c *Client) processResponse(resp *http.Response, attempt, maxRetries int, baseDelay time.Duration, path string) (*Response, bool, error) {
+func (c *Client) processResponse(ctx context.Context, resp *http.Response, attempt, maxRetries int, baseDelay time.Duration, path string) (*Response, bool, error) {
 	defer resp.Body.Close()
 
 	// Handle specific HTTP status codes
@@ -581,7 +965,9 @@ func (c *Client) processResponse(resp *http.Response, attempt, maxRetries int, b
 					"delay", delay,
 					"status", resp.StatusCode)
 			}
-			time.Sleep(delay)
+			if err := sleepOrDone(ctx, delay); err != nil {
+				return nil, false, err
+			}
 			return nil, true, nil
 		}
 		return nil, false, fmt.Errorf("rate limited after %d attempts", maxRetries)
@@ -595,7 +981,9 @@ func (c *Client) processResponse(resp *http.Response, attempt, maxRetries int, b
 					"status", resp.StatusCode,
 					"delay", delay)
 			}
-			time.Sleep(delay)
+			if err := sleepOrDone(ctx, delay); err != nil {
+				return nil, false, err
+			}
 			return nil, true, nil
 		}
 		return nil, false, fmt.Errorf("server error after %d attempts: status %d", maxRetries, resp.StatusCode)
@@ -642,42 +1030,142 @@ func getFloat64(m map[string]interface{}, key string) float64 {
 	return 0.0
 }
 
-// createTLSConfig creates a TLS config that trusts the local mcpproxy CA
-func createTLSConfig(logger *zap.SugaredLogger) *tls.Config {
-	// Start with system cert pool
-	rootCAs, err := x509.SystemCertPool()
-	if err != nil {
-		if logger != nil {
-			logger.Warn("Failed to load system cert pool, creating empty pool", "error", err)
-		}
-		rootCAs = x509.NewCertPool()
-	}
+// buildTLSConfig creates a TLS config for talking to the proxy's admin API, honoring
+// opts for mutual TLS, pinned-CA-only trust, SNI override, and SPKI pinning. With the
+// zero value TLSOptions it reproduces the historical behavior: system cert pool plus
+// the local mcpproxy CA if present, server-verification only.
+func buildTLSConfig(logger *zap.SugaredLogger, opts TLSOptions) (*tls.Config, error) {
+	var rootCAs *x509.CertPool
 
-	// Try to load the local mcpproxy CA certificate
-	caPath := getLocalCAPath()
-	if caPath != "" {
-		if caCert, err := os.ReadFile(caPath); err == nil {
-			if rootCAs.AppendCertsFromPEM(caCert) {
-				if logger != nil {
-					logger.Debug("Successfully loaded local mcpproxy CA certificate", "ca_path", caPath)
+	if opts.PinOnly {
+		// Pin-only mode: the only acceptable root is the local mcpproxy CA, so a
+		// compromised public CA cannot impersonate the local daemon.
+		rootCAs = x509.NewCertPool()
+		caPath := getLocalCAPath()
+		caCert, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("pin-only mode requires the local mcpproxy CA, but it could not be read from %s: %w", caPath, err)
+		}
+		if !rootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("pin-only mode requires the local mcpproxy CA, but %s did not contain a valid PEM certificate", caPath)
+		}
+	} else {
+		// Start with system cert pool
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			if logger != nil {
+				logger.Warn("Failed to load system cert pool, creating empty pool", "error", err)
+			}
+			pool = x509.NewCertPool()
+		}
+		rootCAs = pool
+
+		// Try to load the local mcpproxy CA certificate
+		caPath := getLocalCAPath()
+		if caPath != "" {
+			if caCert, err := os.ReadFile(caPath); err == nil {
+				if rootCAs.AppendCertsFromPEM(caCert) {
+					if logger != nil {
+						logger.Debug("Successfully loaded local mcpproxy CA certificate", "ca_path", caPath)
+					}
+				} else {
+					if logger != nil {
+						logger.Warn("Failed to parse local mcpproxy CA certificate", "ca_path", caPath)
+					}
 				}
 			} else {
 				if logger != nil {
-					logger.Warn("Failed to parse local mcpproxy CA certificate", "ca_path", caPath)
+					logger.Debug("Local mcpproxy CA certificate not found, will use system certs only", "ca_path", caPath)
 				}
 			}
-		} else {
-			if logger != nil {
-				logger.Debug("Local mcpproxy CA certificate not found, will use system certs only", "ca_path", caPath)
-			}
 		}
 	}
 
-	return &tls.Config{
+	tlsConfig := &tls.Config{
 		RootCAs:            rootCAs,
+		ServerName:         opts.ServerName,
 		InsecureSkipVerify: false, // Keep verification enabled for security
 		MinVersion:         tls.VersionTLS12,
 	}
+
+	switch {
+	case opts.ClientCertFile != "" && opts.ClientKeyFile != "":
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate/key pair (%s, %s): %w", opts.ClientCertFile, opts.ClientKeyFile, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case opts.ClientCertFile != "" || opts.ClientKeyFile != "":
+		return nil, fmt.Errorf("mTLS requires both ClientCertFile and ClientKeyFile, got cert=%q key=%q", opts.ClientCertFile, opts.ClientKeyFile)
+	}
+
+	if len(opts.PinnedSPKIHashes) > 0 {
+		pinned := make(map[string]struct{}, len(opts.PinnedSPKIHashes))
+		for _, hash := range opts.PinnedSPKIHashes {
+			pinned[hash] = struct{}{}
+		}
+
+		// Pinning replaces Go's default chain verification, so re-run it ourselves
+		// before checking the SPKI hash. See tls.Config.VerifyPeerCertificate docs.
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyPinnedSPKI(rawCerts, rootCAs, opts.ServerName, pinned)
+		}
+	}
+
+	return tlsConfig, nil
+}
+
+// verifyPinnedSPKI re-verifies the peer chain against rootCAs and additionally requires
+// the leaf certificate's SPKI hash to be one of pinned.
+func verifyPinnedSPKI(rawCerts [][]byte, rootCAs *x509.CertPool, serverName string, pinned map[string]struct{}) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("no peer certificates presented")
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse peer certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	leaf := certs[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         rootCAs,
+		Intermediates: intermediates,
+	}); err != nil {
+		return fmt.Errorf("peer certificate chain verification failed: %w", err)
+	}
+
+	hash, err := spkiSHA256Base64(leaf)
+	if err != nil {
+		return fmt.Errorf("failed to hash peer certificate SPKI: %w", err)
+	}
+	if _, ok := pinned[hash]; !ok {
+		return fmt.Errorf("peer certificate SPKI hash %s is not in the pinned set", hash)
+	}
+
+	return nil
+}
+
+// spkiSHA256Base64 returns the base64-encoded SHA-256 hash of cert's
+// SubjectPublicKeyInfo, in the form commonly used for HPKP/SPKI pinning.
+func spkiSHA256Base64(cert *x509.Certificate) (string, error) {
+	spki, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(spki)
+	return base64.StdEncoding.EncodeToString(sum[:]), nil
 }
 
 // getLocalCAPath returns the path to the local mcpproxy CA certificate
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// generateSelfSignedCert returns PEM-encoded cert/key bytes and the parsed
+// certificate, for use as a throwaway CA or leaf in TLS config tests.
+func generateSelfSignedCert(t *testing.T) (certPEM, keyPEM []byte, cert *x509.Certificate) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "mcpproxy-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse generated certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, parsed
+}
+
+// withCertsDir points MCPPROXY_CERTS_DIR at dir for the duration of the test, so
+// getLocalCAPath resolves to a path we control.
+func withCertsDir(t *testing.T, dir string) {
+	t.Helper()
+	t.Setenv("MCPPROXY_CERTS_DIR", dir)
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	cases := []struct {
+		name            string
+		setup           func(t *testing.T) TLSOptions
+		wantErrContains string
+		check           func(t *testing.T, cfg *tls.Config)
+	}{
+		{
+			name: "pin-only mode requires the CA file to exist",
+			setup: func(t *testing.T) TLSOptions {
+				withCertsDir(t, t.TempDir())
+				return TLSOptions{PinOnly: true}
+			},
+			wantErrContains: "could not be read",
+		},
+		{
+			name: "pin-only mode rejects a malformed CA PEM",
+			setup: func(t *testing.T) TLSOptions {
+				dir := t.TempDir()
+				withCertsDir(t, dir)
+				writeFile(t, filepath.Join(dir, "ca.pem"), []byte("not a certificate"))
+				return TLSOptions{PinOnly: true}
+			},
+			wantErrContains: "did not contain a valid PEM certificate",
+		},
+		{
+			name: "pin-only mode accepts a valid CA",
+			setup: func(t *testing.T) TLSOptions {
+				dir := t.TempDir()
+				withCertsDir(t, dir)
+				certPEM, _, _ := generateSelfSignedCert(t)
+				writeFile(t, filepath.Join(dir, "ca.pem"), certPEM)
+				return TLSOptions{PinOnly: true}
+			},
+			check: func(t *testing.T, cfg *tls.Config) {
+				if cfg.RootCAs == nil {
+					t.Fatal("expected RootCAs to be populated from the pinned CA")
+				}
+			},
+		},
+		{
+			name: "client cert without a key is rejected",
+			setup: func(_ *testing.T) TLSOptions {
+				return TLSOptions{ClientCertFile: "cert.pem"}
+			},
+			wantErrContains: "requires both ClientCertFile and ClientKeyFile",
+		},
+		{
+			name: "client key without a cert is rejected",
+			setup: func(_ *testing.T) TLSOptions {
+				return TLSOptions{ClientKeyFile: "key.pem"}
+			},
+			wantErrContains: "requires both ClientCertFile and ClientKeyFile",
+		},
+		{
+			name: "malformed client cert/key pair is rejected",
+			setup: func(t *testing.T) TLSOptions {
+				dir := t.TempDir()
+				certPath := filepath.Join(dir, "cert.pem")
+				keyPath := filepath.Join(dir, "key.pem")
+				writeFile(t, certPath, []byte("not a cert"))
+				writeFile(t, keyPath, []byte("not a key"))
+				return TLSOptions{ClientCertFile: certPath, ClientKeyFile: keyPath}
+			},
+			wantErrContains: "failed to load client certificate/key pair",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := tc.setup(t)
+
+			cfg, err := buildTLSConfig(nil, opts)
+			if tc.wantErrContains != "" {
+				if err == nil {
+					t.Fatalf("expected an error containing %q, got nil", tc.wantErrContains)
+				}
+				if !strings.Contains(err.Error(), tc.wantErrContains) {
+					t.Fatalf("expected error containing %q, got: %v", tc.wantErrContains, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.check != nil {
+				tc.check(t, cfg)
+			}
+		})
+	}
+}
+
+func TestVerifyPinnedSPKI(t *testing.T) {
+	_, _, cert := generateSelfSignedCert(t)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	matchingHash, err := spkiSHA256Base64(cert)
+	if err != nil {
+		t.Fatalf("failed to hash certificate SPKI: %v", err)
+	}
+
+	cases := []struct {
+		name            string
+		rawCerts        [][]byte
+		roots           *x509.CertPool
+		pinned          map[string]struct{}
+		wantErrContains string
+	}{
+		{
+			name:            "no certificates presented",
+			rawCerts:        nil,
+			roots:           x509.NewCertPool(),
+			pinned:          map[string]struct{}{},
+			wantErrContains: "no peer certificates",
+		},
+		{
+			name:            "mismatched hash is rejected",
+			rawCerts:        [][]byte{cert.Raw},
+			roots:           roots,
+			pinned:          map[string]struct{}{"not-the-real-hash": {}},
+			wantErrContains: "not in the pinned set",
+		},
+		{
+			name:     "matching hash is accepted",
+			rawCerts: [][]byte{cert.Raw},
+			roots:    roots,
+			pinned:   map[string]struct{}{matchingHash: {}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyPinnedSPKI(tc.rawCerts, tc.roots, "", tc.pinned)
+			if tc.wantErrContains != "" {
+				if err == nil {
+					t.Fatalf("expected an error containing %q, got nil", tc.wantErrContains)
+				}
+				if !strings.Contains(err.Error(), tc.wantErrContains) {
+					t.Fatalf("expected error containing %q, got: %v", tc.wantErrContains, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConnectSSEIdleTimeoutForcesReconnect(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("ResponseWriter does not support flushing")
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "event: status\ndata: {}\n\n")
+		flusher.Flush()
+
+		// Hold the connection open without sending anything else, so the idle
+		// watchdog is the only thing that can end it.
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	client.sseIdleTimeout = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.connectSSE(ctx)
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatal("expected connectSSE to return an error once the idle watchdog closed the body")
+	}
+	if elapsed < client.sseIdleTimeout {
+		t.Fatalf("connectSSE returned after %s, before its %s idle timeout even elapsed", elapsed, client.sseIdleTimeout)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("connectSSE took %s to return; the idle watchdog should have forced a reconnect near %s", elapsed, client.sseIdleTimeout)
+	}
+}
+
+func TestStartSSEStableConnectionResetsBackoff(t *testing.T) {
+	var attempts int32
+	var mu sync.Mutex
+	var secondAttemptClosed, thirdAttemptStarted time.Time
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&attempts, 1) {
+		case 1:
+			// First attempt fails immediately, forcing the retry loop to back off
+			// before trying again.
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			flusher, ok := w.(http.Flusher)
+			if !ok {
+				t.Error("ResponseWriter does not support flushing")
+				return
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, "event: status\ndata: {}\n\n")
+			flusher.Flush()
+			time.Sleep(80 * time.Millisecond) // outlast the test's sseStableAfter
+			mu.Lock()
+			secondAttemptClosed = time.Now()
+			mu.Unlock()
+			// Return normally: a clean EOF, not an error.
+		default:
+			mu.Lock()
+			thirdAttemptStarted = time.Now()
+			mu.Unlock()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv.URL)
+	client.sseStableAfter = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := client.StartSSE(ctx); err != nil {
+		t.Fatalf("StartSSE returned an error: %v", err)
+	}
+	defer client.StopSSE()
+
+	deadline := time.After(6 * time.Second)
+	for {
+		mu.Lock()
+		started := !thirdAttemptStarted.IsZero()
+		mu.Unlock()
+		if started {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatal("timed out waiting for the third connection attempt")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	mu.Lock()
+	gap := thirdAttemptStarted.Sub(secondAttemptClosed)
+	mu.Unlock()
+
+	// Attempt 1 failed instantly, so without a reset the third attempt would sit
+	// behind the exponential backoff for attempt 3 (well over a second). A reset
+	// backoff instead reconnects immediately, as if this were attempt 1 again.
+	if gap > time.Second {
+		t.Fatalf("reconnect after a stable connection took %s; backoff should have reset instead of continuing to climb", gap)
+	}
+}
@@ -0,0 +1,143 @@
+// Package cli implements the mcpproxy-tray CLI observer subcommands (status, watch),
+// letting users script against the tray's API client without the GUI tray itself,
+// e.g. `mcpproxy-tray watch --format=json | jq ...`.
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/api"
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/output"
+)
+
+// Run dispatches to the requested subcommand ("status" or "watch"). It mirrors the
+// flag conventions of the rest of the mcpproxy toolchain: a subcommand name followed
+// by its own flag set.
+func Run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: mcpproxy-tray <status|watch> [flags]")
+	}
+
+	switch args[0] {
+	case "status":
+		return runStatus(args[1:], stdout)
+	case "watch":
+		return runWatch(args[1:], stdout)
+	default:
+		return fmt.Errorf("unknown subcommand %q (want %q or %q)", args[0], "status", "watch")
+	}
+}
+
+// commonFlags are shared between status and watch.
+type commonFlags struct {
+	baseURL string
+	apiKey  string
+	format  string
+}
+
+func bindCommonFlags(fs *flag.FlagSet) *commonFlags {
+	f := &commonFlags{}
+	fs.StringVar(&f.baseURL, "base-url", "https://127.0.0.1:8080", "mcpproxy admin API base URL")
+	fs.StringVar(&f.apiKey, "api-key", os.Getenv("MCPPROXY_API_KEY"), "mcpproxy admin API key")
+	fs.StringVar(&f.format, "format", output.FormatDocumentation, "output format: documentation, json, or prom")
+	return f
+}
+
+func newClient(f *commonFlags) (*api.Client, error) {
+	logger := zap.NewNop().Sugar()
+	client, err := api.NewClient(f.baseURL, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+	client.SetAPIKey(f.apiKey)
+	return client, nil
+}
+
+func runStatus(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	f := bindCommonFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newClient(f)
+	if err != nil {
+		return err
+	}
+
+	out, err := output.New(f.format, stdout)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	servers, err := client.GetServers(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch servers: %w", err)
+	}
+
+	return out.Servers(servers)
+}
+
+func runWatch(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	f := bindCommonFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newClient(f)
+	if err != nil {
+		return err
+	}
+
+	out, err := output.New(f.format, stdout)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if err := client.StartSSE(ctx); err != nil {
+		return fmt.Errorf("failed to start SSE watch: %w", err)
+	}
+	defer client.StopSSE()
+
+	statuses := client.StatusChannel()
+	events := client.EventChannel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-statuses:
+			if !ok {
+				return nil
+			}
+			if err := out.StatusUpdate(update); err != nil {
+				return fmt.Errorf("failed to render status update: %w", err)
+			}
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.Type == "status" {
+				// Rendered via StatusChannel above.
+				continue
+			}
+			if err := out.Event(event); err != nil {
+				return fmt.Errorf("failed to render event: %w", err)
+			}
+		}
+	}
+}
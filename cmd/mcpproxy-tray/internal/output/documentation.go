@@ -0,0 +1,68 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/api"
+)
+
+// ANSI color codes used for the documentation formatter's status glyphs.
+const (
+	colorGreen  = "\x1b[32m"
+	colorRed    = "\x1b[31m"
+	colorYellow = "\x1b[33m"
+	colorReset  = "\x1b[0m"
+)
+
+// documentationOutputer renders a colorized, human-readable summary, in the spirit of
+// RSpec's "documentation" formatter: one line per item, grouped and easy to scan.
+type documentationOutputer struct {
+	w io.Writer
+}
+
+func newDocumentationOutputer(w io.Writer) *documentationOutputer {
+	return &documentationOutputer{w: w}
+}
+
+func (o *documentationOutputer) Servers(servers []api.Server) error {
+	for _, s := range servers {
+		glyph, color := serverGlyph(s)
+		if _, err := fmt.Fprintf(o.w, "%s%s%s %s (%s, %d tools)\n", color, glyph, colorReset, s.Name, s.Protocol, s.ToolCount); err != nil {
+			return err
+		}
+		if s.LastError != "" {
+			if _, err := fmt.Fprintf(o.w, "    %slast error: %s%s\n", colorRed, s.LastError, colorReset); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (o *documentationOutputer) StatusUpdate(update api.StatusUpdate) error {
+	state := colorRed + "stopped" + colorReset
+	if update.Running {
+		state = colorGreen + "running" + colorReset
+	}
+	_, err := fmt.Fprintf(o.w, "status: %s (listening on %s)\n", state, update.ListenAddr)
+	return err
+}
+
+func (o *documentationOutputer) Event(event api.Event) error {
+	_, err := fmt.Fprintf(o.w, "%sevent%s %s: %s\n", colorYellow, colorReset, event.Type, event.Data)
+	return err
+}
+
+func serverGlyph(s api.Server) (string, string) {
+	switch {
+	case s.Quarantined:
+		return "!", colorYellow
+	case s.Connected:
+		return "✓", colorGreen
+	case s.Connecting:
+		return "~", colorYellow
+	default:
+		return "✗", colorRed
+	}
+}
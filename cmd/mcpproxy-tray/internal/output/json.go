@@ -0,0 +1,37 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/api"
+)
+
+// jsonOutputer renders each item as a single line of JSON (NDJSON), suitable for
+// piping into jq or another tool's own ingestion pipeline.
+type jsonOutputer struct {
+	enc *json.Encoder
+}
+
+func newJSONOutputer(w io.Writer) *jsonOutputer {
+	return &jsonOutputer{enc: json.NewEncoder(w)}
+}
+
+// jsonRecord is the envelope written for every line, so a consumer can dispatch on
+// "kind" without having to guess the shape of "payload".
+type jsonRecord struct {
+	Kind    string      `json:"kind"`
+	Payload interface{} `json:"payload"`
+}
+
+func (o *jsonOutputer) Servers(servers []api.Server) error {
+	return o.enc.Encode(jsonRecord{Kind: "servers", Payload: servers})
+}
+
+func (o *jsonOutputer) StatusUpdate(update api.StatusUpdate) error {
+	return o.enc.Encode(jsonRecord{Kind: "status", Payload: update})
+}
+
+func (o *jsonOutputer) Event(event api.Event) error {
+	return o.enc.Encode(jsonRecord{Kind: "event", Payload: event})
+}
@@ -0,0 +1,49 @@
+// Package output renders API client data for the mcpproxy-tray CLI observer
+// subcommands (status, watch). New selects one of three Outputer implementations by
+// name: "documentation" for a human-readable terminal summary, "json" for
+// newline-delimited events meant to be piped into other tooling, and "prom" for
+// Prometheus textfile-collector metrics. Callers depend only on the Outputer
+// interface, so status/watch don't need to know which format is in play.
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/api"
+)
+
+// Outputer renders status updates and server snapshots for a specific audience: a
+// human terminal, a log aggregator, or a metrics scraper.
+type Outputer interface {
+	// Servers renders a full snapshot of the known servers, e.g. for `status`.
+	Servers(servers []api.Server) error
+	// StatusUpdate renders a single SSE status update, e.g. during `watch`.
+	StatusUpdate(update api.StatusUpdate) error
+	// Event renders a single typed SSE event other than "status".
+	Event(event api.Event) error
+}
+
+// Format names accepted by New.
+const (
+	FormatDocumentation = "documentation"
+	FormatJSON          = "json"
+	FormatPrometheus    = "prom"
+)
+
+// New returns the Outputer registered for format, writing to w. Supported formats are
+// "documentation" (colorized human summary), "json" (newline-delimited JSON events
+// suitable for piping into other tooling), and "prom" (Prometheus textfile-collector
+// metrics for scraping).
+func New(format string, w io.Writer) (Outputer, error) {
+	switch format {
+	case "", FormatDocumentation:
+		return newDocumentationOutputer(w), nil
+	case FormatJSON:
+		return newJSONOutputer(w), nil
+	case FormatPrometheus:
+		return newPrometheusOutputer(w), nil
+	default:
+		return nil, fmt.Errorf("unknown output format %q (want %q, %q, or %q)", format, FormatDocumentation, FormatJSON, FormatPrometheus)
+	}
+}
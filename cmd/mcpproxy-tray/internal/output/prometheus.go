@@ -0,0 +1,66 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/api"
+)
+
+// prometheusOutputer renders Prometheus textfile-collector metrics: a full file body
+// on each call, matching the exposition format node_exporter's textfile collector
+// expects (https://github.com/prometheus/node_exporter#textfile-collector).
+type prometheusOutputer struct {
+	w io.Writer
+}
+
+func newPrometheusOutputer(w io.Writer) *prometheusOutputer {
+	return &prometheusOutputer{w: w}
+}
+
+func (o *prometheusOutputer) Servers(servers []api.Server) error {
+	var b strings.Builder
+	b.WriteString("# HELP mcpproxy_server_connected Whether the upstream server is currently connected.\n")
+	b.WriteString("# TYPE mcpproxy_server_connected gauge\n")
+	for _, s := range servers {
+		fmt.Fprintf(&b, "mcpproxy_server_connected{name=%q,protocol=%q} %d\n", s.Name, s.Protocol, boolToFloat(s.Connected))
+	}
+
+	b.WriteString("# HELP mcpproxy_server_enabled Whether the upstream server is enabled.\n")
+	b.WriteString("# TYPE mcpproxy_server_enabled gauge\n")
+	for _, s := range servers {
+		fmt.Fprintf(&b, "mcpproxy_server_enabled{name=%q} %d\n", s.Name, boolToFloat(s.Enabled))
+	}
+
+	b.WriteString("# HELP mcpproxy_server_tool_count Number of tools exposed by the upstream server.\n")
+	b.WriteString("# TYPE mcpproxy_server_tool_count gauge\n")
+	for _, s := range servers {
+		fmt.Fprintf(&b, "mcpproxy_server_tool_count{name=%q} %d\n", s.Name, s.ToolCount)
+	}
+
+	_, err := io.WriteString(o.w, b.String())
+	return err
+}
+
+func (o *prometheusOutputer) StatusUpdate(update api.StatusUpdate) error {
+	var b strings.Builder
+	b.WriteString("# HELP mcpproxy_running Whether the mcpproxy daemon reports itself as running.\n")
+	b.WriteString("# TYPE mcpproxy_running gauge\n")
+	fmt.Fprintf(&b, "mcpproxy_running %d\n", boolToFloat(update.Running))
+	_, err := io.WriteString(o.w, b.String())
+	return err
+}
+
+// Event is a no-op for the Prometheus formatter: arbitrary typed events don't map to
+// a stable metric name, so only Servers and StatusUpdate produce output.
+func (o *prometheusOutputer) Event(_ api.Event) error {
+	return nil
+}
+
+func boolToFloat(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,23 @@
+// Command mcpproxy-tray is the tray's CLI entry point. This tree currently wires up
+// only the CLI observer subcommands (status, watch); the systray GUI mode lives
+// elsewhere in the full mcpproxy-tray build and is out of scope here.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"mcpproxy-go/cmd/mcpproxy-tray/internal/cli"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: mcpproxy-tray <status|watch> [flags]")
+		os.Exit(1)
+	}
+
+	if err := cli.Run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "mcpproxy-tray:", err)
+		os.Exit(1)
+	}
+}